@@ -6,38 +6,25 @@ package duckdb
 import "C"
 
 import (
-	"bytes"
 	"database/sql/driver"
 	"errors"
-	"io"
-	"reflect"
-	"strconv"
-	"strings"
 	"sync"
-	"time"
 	"unsafe"
 )
 
-var (
-	errInvalidType = errors.New("invalid data type")
-)
-
 type conn struct {
-	mu  sync.Mutex
-	db  *C.duckdb_database
-	con *C.duckdb_connection
+	mu        sync.Mutex
+	db        *C.duckdb_database
+	con       *C.duckdb_connection
+	readOnly  bool
+	connector *Connector
+	stmtCache *stmtCache
 }
 
 type duckdbResult struct {
 	ra int64
 }
 
-type duckdbRows struct {
-	r      *C.duckdb_result
-	s      *stmt
-	cursor int64
-}
-
 func (r duckdbResult) LastInsertId() (int64, error) {
 	return 0, nil
 }
@@ -46,165 +33,16 @@ func (r duckdbResult) RowsAffected() (int64, error) {
 	return r.ra, nil
 }
 
-func (r *duckdbRows) Columns() []string {
-	if r.r == nil {
-		panic("database/sql/driver: misuse of duckdb driver: Columns of closed rows")
-	}
-
-	columnCount := C.duckdb_column_count(r.r)
-
-	cols := make([]string, int64(columnCount))
-	for i := 0; i < int(columnCount); i++ {
-		cols[i] = C.GoString(C.duckdb_column_name(r.r, C.idx_t(i)))
-	}
-
-	return cols
-}
-
-func (r *duckdbRows) Next(dst []driver.Value) error {
-	if r.r == nil {
-		panic("database/sql/driver: misuse of duckdb driver: Next of closed rows")
-	}
-
-	if r.cursor >= int64(C.duckdb_row_count(r.r)) {
-		return io.EOF
-	}
-
-	columnCount := C.duckdb_column_count(r.r)
-
-	for i := 0; i < int(columnCount); i++ {
-		colType := C.duckdb_column_type(r.r, C.idx_t(i))
-		colData := C.duckdb_column_data(r.r, C.idx_t(i))
-		switch colType {
-		case C.DUCKDB_TYPE_INVALID:
-			return errInvalidType
-		case C.DUCKDB_TYPE_BOOLEAN:
-			dst[i] = (*[1 << 31]bool)(unsafe.Pointer(colData))[r.cursor]
-		case C.DUCKDB_TYPE_TINYINT:
-			dst[i] = (*[1 << 31]int8)(unsafe.Pointer(colData))[r.cursor]
-		case C.DUCKDB_TYPE_SMALLINT:
-			dst[i] = (*[1 << 31]int16)(unsafe.Pointer(colData))[r.cursor]
-		case C.DUCKDB_TYPE_INTEGER:
-			dst[i] = (*[1 << 31]int32)(unsafe.Pointer(colData))[r.cursor]
-		case C.DUCKDB_TYPE_BIGINT:
-			dst[i] = (*[1 << 31]int64)(unsafe.Pointer(colData))[r.cursor]
-		case C.DUCKDB_TYPE_FLOAT:
-			dst[i] = (*[1 << 31]float32)(unsafe.Pointer(colData))[r.cursor]
-		case C.DUCKDB_TYPE_DOUBLE:
-			dst[i] = (*[1 << 31]float64)(unsafe.Pointer(colData))[r.cursor]
-		case C.DUCKDB_TYPE_DATE:
-			date := (*[1 << 31]C.duckdb_date)(unsafe.Pointer(colData))[r.cursor]
-			val := C.duckdb_from_date(date)
-			dst[i] = time.Date(
-				int(val.year),
-				time.Month(val.month),
-				int(val.day),
-				0, 0, 0, 0,
-				time.UTC,
-			)
-		case C.DUCKDB_TYPE_VARCHAR:
-			dst[i] = C.GoString((*[1 << 31]*C.char)(unsafe.Pointer(colData))[r.cursor])
-		case C.DUCKDB_TYPE_TIMESTAMP:
-			ts := (*[1 << 31]C.duckdb_timestamp)(unsafe.Pointer(colData))[r.cursor]
-			val := C.duckdb_from_timestamp(ts)
-			dst[i] = time.Date(
-				int(val.date.year),
-				time.Month(val.date.month),
-				int(val.date.day),
-				int(val.time.hour),
-				int(val.time.min),
-				int(val.time.sec),
-				int(val.time.micros),
-				time.UTC,
-			)
-		}
-	}
-
-	r.cursor++
-
-	return nil
-}
-
-// implements driver.RowsColumnTypeScanType
-func (r *duckdbRows) ColumnTypeScanType(index int) reflect.Type {
-	colType := C.duckdb_column_type(r.r, C.idx_t(index))
-	switch colType {
-	case C.DUCKDB_TYPE_BOOLEAN:
-		return reflect.TypeOf(true)
-	case C.DUCKDB_TYPE_TINYINT:
-		return reflect.TypeOf(int8(0))
-	case C.DUCKDB_TYPE_SMALLINT:
-		return reflect.TypeOf(int16(0))
-	case C.DUCKDB_TYPE_INTEGER:
-		return reflect.TypeOf(int(0))
-	case C.DUCKDB_TYPE_BIGINT:
-		return reflect.TypeOf(int64(0))
-	case C.DUCKDB_TYPE_FLOAT:
-		return reflect.TypeOf(float32(0))
-	case C.DUCKDB_TYPE_DOUBLE:
-		return reflect.TypeOf(float64(0))
-	case C.DUCKDB_TYPE_DATE, C.DUCKDB_TYPE_TIMESTAMP:
-		return reflect.TypeOf(time.Time{})
-	case C.DUCKDB_TYPE_VARCHAR:
-		return reflect.TypeOf("")
-	}
-	return nil
-}
-
-// implements driver.RowsColumnTypeScanType
-func (r *duckdbRows) ColumnTypeDatabaseTypeName(index int) string {
-	colType := C.duckdb_column_type(r.r, C.idx_t(index))
-	switch colType {
-	case C.DUCKDB_TYPE_BOOLEAN:
-		return "BOOLEAN"
-	case C.DUCKDB_TYPE_TINYINT:
-		return "TINYINT"
-	case C.DUCKDB_TYPE_SMALLINT:
-		return "SMALLINT"
-	case C.DUCKDB_TYPE_INTEGER:
-		return "INT"
-	case C.DUCKDB_TYPE_BIGINT:
-		return "BIGINT"
-	case C.DUCKDB_TYPE_FLOAT:
-		return "FLOAT"
-	case C.DUCKDB_TYPE_DOUBLE:
-		return "DOUBLE"
-	case C.DUCKDB_TYPE_DATE:
-		return "DATE"
-	case C.DUCKDB_TYPE_VARCHAR:
-		return "VARCHAR"
-	case C.DUCKDB_TYPE_TIMESTAMP:
-		return "TIMESTAMP"
-	}
-	return ""
-}
-
-func (r *duckdbRows) Close() error {
-	if r.r == nil {
-		panic("database/sql/driver: misuse of duckdb driver: Close of already closed rows")
-	}
-
-	C.duckdb_destroy_result(r.r)
-
-	r.r = nil
-	if r.s != nil {
-		r.s.rows = false
-		r.s = nil
-	}
-
-	return nil
-}
-
 func (c *conn) Exec(query string, args []driver.Value) (driver.Result, error) {
-	res, err := c.exec(query)
+	s, owned, err := c.stmtCache.prepare(c, query)
 	if err != nil {
 		return nil, err
 	}
-	defer C.duckdb_destroy_result(res)
-
-	ra := int64(C.duckdb_value_int64(res, 0, 0))
+	if owned {
+		defer s.destroy()
+	}
 
-	return duckdbResult{ra: ra}, nil
+	return s.Exec(args)
 }
 
 func (c *conn) Query(query string, args []driver.Value) (driver.Rows, error) {
@@ -212,11 +50,22 @@ func (c *conn) Query(query string, args []driver.Value) (driver.Rows, error) {
 }
 
 func (c *conn) Prepare(cmd string) (driver.Stmt, error) {
-	cmdstr := C.CString(cmd)
+	return c.prepareNew(cmd)
+}
+
+// prepareNew always prepares a fresh duckdb_prepared_statement, bypassing
+// the cache. Used both for driver.Conn.Prepare (the statement is then owned
+// by the caller) and by stmtCache to fill a cache miss.
+func (c *conn) prepareNew(query string) (*stmt, error) {
+	cmdstr := C.CString(query)
 	defer C.free(unsafe.Pointer(cmdstr))
 
 	var s C.duckdb_prepared_statement
-	C.duckdb_prepare(*c.con, cmdstr, &s)
+	if state := C.duckdb_prepare(*c.con, cmdstr, &s); state == C.DuckDBError {
+		err := errors.New(C.GoString(C.duckdb_prepare_error(s)))
+		C.duckdb_destroy_prepare(&s)
+		return nil, err
+	}
 
 	return &stmt{c: c, stmt: &s}, nil
 }
@@ -237,24 +86,32 @@ func (c *conn) Close() error {
 	}
 
 	C.duckdb_disconnect(c.con)
-	C.duckdb_close(c.db)
+	c.stmtCache.close()
+	if c.connector != nil {
+		c.connector.release()
+	}
 	c.db = nil
 
 	return nil
 }
 
+// query prepares (or reuses a cached preparation of) query and binds args
+// through the same path stmt.Query uses, rather than interpolating args
+// into the SQL text.
 func (c *conn) query(query string, args []driver.Value) (driver.Rows, error) {
-	queryStr, err := c.interpolateParams(query, args)
+	s, owned, err := c.stmtCache.prepare(c, query)
 	if err != nil {
 		return nil, err
 	}
-
-	res, err := c.exec(queryStr)
-	if err != nil {
-		return nil, err
+	if owned {
+		// The duckdb_result returned by Query is independent of the
+		// prepared statement once execution completes, so it's safe to
+		// destroy a one-off (uncached) statement right away instead of
+		// leaking it.
+		defer s.destroy()
 	}
 
-	return &duckdbRows{r: res}, nil
+	return s.Query(args)
 }
 
 func (c *conn) exec(cmd string) (*C.duckdb_result, error) {
@@ -270,96 +127,6 @@ func (c *conn) exec(cmd string) (*C.duckdb_result, error) {
 	return &res, nil
 }
 
-// interpolateParams is taken from
-// https://github.com/go-sql-driver/mysql
-func (c *conn) interpolateParams(query string, args []driver.Value) (string, error) {
-	if strings.Count(query, "?") != len(args) {
-		return "", driver.ErrSkip
-	}
-
-	buf := []byte{}
-	argPos := 0
-
-	for i := 0; i < len(query); i++ {
-		q := strings.IndexByte(query[i:], '?')
-		if q == -1 {
-			buf = append(buf, query[i:]...)
-			break
-		}
-		buf = append(buf, query[i:i+q]...)
-		i += q
-
-		arg := args[argPos]
-		argPos++
-
-		if arg == nil {
-			buf = append(buf, "NULL"...)
-			continue
-		}
-
-		switch v := arg.(type) {
-		case int8:
-			buf = strconv.AppendInt(buf, int64(v), 10)
-		case int16:
-			buf = strconv.AppendInt(buf, int64(v), 10)
-		case int32:
-			buf = strconv.AppendInt(buf, int64(v), 10)
-		case int64:
-			buf = strconv.AppendInt(buf, int64(v), 10)
-		case float64:
-			buf = strconv.AppendFloat(buf, v, 'g', -1, 64)
-		case bool:
-			if v {
-				buf = append(buf, '1')
-			} else {
-				buf = append(buf, '0')
-			}
-		case time.Time:
-			buf = strconv.AppendInt(buf, v.Unix(), 10)
-		case string:
-			buf = append(buf, '\'')
-			buf = append(buf, escapeValue(v)...)
-			buf = append(buf, '\'')
-		default:
-			return "", driver.ErrSkip
-		}
-	}
-
-	if argPos != len(args) {
-		return "", driver.ErrSkip
-	}
-
-	return string(buf), nil
-}
-
-func escapeValue(v string) []byte {
-	buf := bytes.NewBuffer(nil)
-
-	for i := 0; i < len(v); i++ {
-		c := v[i]
-		switch c {
-		case '\x00':
-			buf.WriteString("\\\\0")
-		case '\n':
-			buf.WriteString("\\\\n")
-		case '\r':
-			buf.WriteString("\\\\r")
-		case '\x1a':
-			buf.WriteString("\\\\Z")
-		case '\'':
-			buf.WriteString("\\\\'")
-		case '"':
-			buf.WriteString("\\\"")
-		case '\\':
-			buf.WriteString("\\\\")
-		default:
-			buf.WriteByte(c)
-		}
-	}
-
-	return buf.Bytes()
-}
-
 type duckdbTx struct {
 	c *conn
 }
@@ -0,0 +1,89 @@
+package duckdb
+
+/*
+#include <duckdb.h>
+*/
+import "C"
+
+import (
+	"context"
+	"database/sql/driver"
+	"sync"
+)
+
+// Connector owns a single duckdb_database opened once per DSN and hands out
+// cheap duckdb_connect connections from it, so a database/sql pool of N
+// connections shares one DuckDB instance instead of opening N of them.
+type Connector struct {
+	mu          sync.Mutex
+	db          *C.duckdb_database
+	opts        dsnOptions
+	refs        int
+	bootQueries []string
+}
+
+// NewConnector opens dsn once and returns a driver.Connector that can be
+// passed to sql.OpenDB. bootQueries, if non-empty, are run on every
+// connection handed out by Connect (e.g. to register UDFs or run
+// INSTALL/LOAD statements once per connection).
+func NewConnector(dsn string, bootQueries []string) (driver.Connector, error) {
+	db, opts, err := openDatabase(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Connector{db: db, opts: opts, bootQueries: bootQueries}, nil
+}
+
+// Connect implements driver.Connector.
+func (c *Connector) Connect(ctx context.Context) (driver.Conn, error) {
+	c.mu.Lock()
+	if c.db == nil {
+		c.mu.Unlock()
+		return nil, errError
+	}
+
+	var con C.duckdb_connection
+	if err := C.duckdb_connect(*c.db, &con); err == C.DuckDBError {
+		c.mu.Unlock()
+		return nil, errError
+	}
+	c.refs++
+	c.mu.Unlock()
+
+	dc := &conn{
+		db:        c.db,
+		con:       &con,
+		readOnly:  c.opts.readOnly,
+		connector: c,
+		stmtCache: newStmtCache(c.opts.stmtCacheSize),
+	}
+
+	for _, q := range c.bootQueries {
+		if _, err := dc.exec(q); err != nil {
+			dc.Close()
+			return nil, err
+		}
+	}
+
+	return dc, nil
+}
+
+// Driver implements driver.Connector.
+func (c *Connector) Driver() driver.Driver {
+	return duckdb{}
+}
+
+// release drops a reference acquired by Connect, closing the shared
+// duckdb_database once the last connection handed out by this Connector has
+// been closed.
+func (c *Connector) release() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.refs--
+	if c.refs <= 0 && c.db != nil {
+		C.duckdb_close(c.db)
+		c.db = nil
+	}
+}
@@ -0,0 +1,225 @@
+package duckdb
+
+/*
+#include <duckdb.h>
+*/
+import "C"
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"math/big"
+	"time"
+)
+
+var errNamedParamsNotSupported = errors.New("duckdb: named parameters are not supported")
+
+// Compile-time assertions that conn/stmt actually implement the
+// database/sql/driver context-aware interfaces, so a renamed or
+// mis-signatured method here fails the build instead of silently falling
+// back to the legacy non-context path.
+var (
+	_ driver.ConnPrepareContext = (*conn)(nil)
+	_ driver.ConnBeginTx        = (*conn)(nil)
+	_ driver.ExecerContext      = (*conn)(nil)
+	_ driver.QueryerContext     = (*conn)(nil)
+	_ driver.StmtExecContext    = (*stmt)(nil)
+	_ driver.StmtQueryContext   = (*stmt)(nil)
+)
+
+// namedValuesToValues drops the positional/name metadata database/sql
+// attaches for the context-aware driver interfaces, since duckdb only binds
+// parameters by position.
+func namedValuesToValues(named []driver.NamedValue) ([]driver.Value, error) {
+	args := make([]driver.Value, len(named))
+	for i, n := range named {
+		if n.Name != "" {
+			return nil, errNamedParamsNotSupported
+		}
+		args[i] = n.Value
+	}
+	return args, nil
+}
+
+// watchCancel spawns a goroutine that interrupts the connection's running
+// query when ctx is done. The returned func must be called once the C call
+// this guards has returned, to stop the watcher.
+func (c *conn) watchCancel(ctx context.Context) func() {
+	if ctx.Done() == nil {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			c.mu.Lock()
+			if c.db != nil {
+				C.duckdb_interrupt(*c.con)
+			}
+			c.mu.Unlock()
+		case <-done:
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+// ctxErr prefers ctx.Err() over err when the context is what actually ended
+// the call, so callers see context.Canceled/DeadlineExceeded rather than
+// whatever error string duckdb_interrupt produced.
+func ctxErr(ctx context.Context, err error) error {
+	if err != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	dargs, err := namedValuesToValues(args)
+	if err != nil {
+		return nil, err
+	}
+
+	cancel := c.watchCancel(ctx)
+	defer cancel()
+
+	res, err := c.Exec(query, dargs)
+	if err != nil {
+		return nil, ctxErr(ctx, err)
+	}
+	return res, nil
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	dargs, err := namedValuesToValues(args)
+	if err != nil {
+		return nil, err
+	}
+
+	cancel := c.watchCancel(ctx)
+	defer cancel()
+
+	// duckdb_interrupt only affects a query that is already running, so a
+	// context that is done before the call starts is reported up front.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err := c.Query(query, dargs)
+	if err != nil {
+		return nil, ctxErr(ctx, err)
+	}
+	return rows, nil
+}
+
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	cancel := c.watchCancel(ctx)
+	defer cancel()
+
+	s, err := c.Prepare(query)
+	if err != nil {
+		return nil, ctxErr(ctx, err)
+	}
+	return s, nil
+}
+
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if opts.Isolation != driver.IsolationLevel(0) {
+		return nil, errors.New("duckdb: isolation levels other than the default are not supported")
+	}
+
+	if !opts.ReadOnly && c.readOnly {
+		return nil, errors.New("duckdb: cannot start a read-write transaction on a read-only connection")
+	}
+
+	cancel := c.watchCancel(ctx)
+	defer cancel()
+
+	beginStmt := "BEGIN TRANSACTION"
+	if opts.ReadOnly {
+		beginStmt = "BEGIN TRANSACTION READ ONLY"
+	}
+
+	if _, err := c.exec(beginStmt); err != nil {
+		return nil, ctxErr(ctx, err)
+	}
+
+	return &duckdbTx{c}, nil
+}
+
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	dargs, err := namedValuesToValues(args)
+	if err != nil {
+		return nil, err
+	}
+
+	cancel := s.c.watchCancel(ctx)
+	defer cancel()
+
+	res, err := s.Exec(dargs)
+	if err != nil {
+		return nil, ctxErr(ctx, err)
+	}
+	return res, nil
+}
+
+// CheckNamedValue implements driver.NamedValueChecker on conn itself, so
+// that the extra types start/appendValue accept are preserved for
+// conn.ExecContext/conn.QueryContext, not just the Prepare path.
+func (c *conn) CheckNamedValue(nv *driver.NamedValue) error {
+	return checkNamedValue(nv)
+}
+
+// checkNamedValue is shared by conn and stmt: it accepts the Go types our
+// bind/append paths know how to translate to duckdb_bind_*/duckdb_append_*
+// calls, normalizing sql.NullXxx into the wrapped value or nil.
+func checkNamedValue(nv *driver.NamedValue) error {
+	switch v := nv.Value.(type) {
+	case nil, bool, int8, int16, int32, int64, int, uint8, uint16, uint32, uint64,
+		float32, float64, string, []byte, time.Time, *big.Int, Interval:
+		return nil
+	case sql.NullString:
+		nv.Value = nullableValue(v.Valid, v.String)
+	case sql.NullInt16:
+		nv.Value = nullableValue(v.Valid, v.Int16)
+	case sql.NullInt32:
+		nv.Value = nullableValue(v.Valid, v.Int32)
+	case sql.NullInt64:
+		nv.Value = nullableValue(v.Valid, v.Int64)
+	case sql.NullFloat64:
+		nv.Value = nullableValue(v.Valid, v.Float64)
+	case sql.NullBool:
+		nv.Value = nullableValue(v.Valid, v.Bool)
+	case sql.NullTime:
+		nv.Value = nullableValue(v.Valid, v.Time)
+	default:
+		return driver.ErrSkip
+	}
+	return nil
+}
+
+func nullableValue(valid bool, v any) any {
+	if !valid {
+		return nil
+	}
+	return v
+}
+
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	dargs, err := namedValuesToValues(args)
+	if err != nil {
+		return nil, err
+	}
+
+	cancel := s.c.watchCancel(ctx)
+	defer cancel()
+
+	rows, err := s.Query(dargs)
+	if err != nil {
+		return nil, ctxErr(ctx, err)
+	}
+	return rows, nil
+}
@@ -0,0 +1,70 @@
+//go:build duckdb_integration
+
+package duckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+)
+
+// These tests exercise the context-aware driver interfaces against a real
+// in-memory duckdb database, so they're gated behind the duckdb_integration
+// build tag: `go test -tags duckdb_integration ./...`.
+
+func TestConnBeginTxRejectsNonDefaultIsolation(t *testing.T) {
+	c := openTestConn(t, "")
+
+	_, err := c.BeginTx(context.Background(), driver.TxOptions{Isolation: driver.IsolationLevel(1)})
+	if err == nil {
+		t.Fatal("BeginTx with a non-default isolation level should be rejected")
+	}
+}
+
+func TestConnBeginTxReadOnlyOnReadOnlyConnection(t *testing.T) {
+	c := openTestConn(t, "?access_mode=READ_ONLY")
+
+	if _, err := c.BeginTx(context.Background(), driver.TxOptions{}); err == nil {
+		t.Fatal("BeginTx without ReadOnly on a read-only connection should be rejected")
+	}
+
+	tx, err := c.BeginTx(context.Background(), driver.TxOptions{ReadOnly: true})
+	if err != nil {
+		t.Fatalf("BeginTx with ReadOnly on a read-only connection: %v", err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("Rollback: %v", err)
+	}
+}
+
+func TestQueryContextCancellation(t *testing.T) {
+	c := openTestConn(t, "")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := c.QueryContext(ctx, "SELECT * FROM range(100000000)", nil)
+	if err == nil {
+		t.Fatal("QueryContext with an already-canceled context should return an error")
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("QueryContext error = %v, want context.Canceled", err)
+	}
+}
+
+func TestPrepareContextCancellation(t *testing.T) {
+	c := openTestConn(t, "")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	time.Sleep(time.Millisecond)
+
+	// PrepareContext's watcher should at least engage without panicking;
+	// whether duckdb_prepare itself completes before the interrupt fires is
+	// a race, so only the interface wiring is asserted here.
+	if _, err := c.PrepareContext(ctx, "SELECT 1"); err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("PrepareContext error = %v, want nil or context.DeadlineExceeded", err)
+	}
+}
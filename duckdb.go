@@ -7,10 +7,12 @@ package duckdb
 import "C"
 
 import (
+	"context"
 	"database/sql"
 	"database/sql/driver"
 	"errors"
 	"net/url"
+	"strconv"
 	"strings"
 	"unsafe"
 )
@@ -19,20 +21,55 @@ var (
 	errError = errors.New("could not open database")
 )
 
+// defaultStmtCacheSize bounds the per-connection prepared-statement cache
+// used by conn.Query/conn.Exec, overridable via the stmt_cache_size DSN
+// parameter.
+const defaultStmtCacheSize = 100
+
+// dsnOptions holds driver-level options parsed out of the DSN query string
+// that are not themselves duckdb_config flags.
+type dsnOptions struct {
+	readOnly      bool
+	stmtCacheSize int
+}
+
 func init() {
 	sql.Register("duckdb", duckdb{})
 }
 
 type duckdb struct{}
 
+// Open opens dsn via a one-off Connector and returns a single connection.
+// database/sql itself never calls this: because duckdb implements
+// driver.DriverContext, sql.Open calls OpenConnector exactly once and pools
+// connections through the resulting Connector, so sql.Open("duckdb", dsn)
+// already shares one duckdb_database across the pool. Use NewConnector
+// directly only when bootQueries need to run on every pooled connection.
 func (d duckdb) Open(dsn string) (driver.Conn, error) {
+	connector, err := d.OpenConnector(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	return connector.Connect(context.Background())
+}
+
+// OpenConnector implements driver.DriverContext so that database/sql pools
+// connections through a single Connector (and therefore a single
+// duckdb_database) instead of calling Open per pooled connection.
+func (d duckdb) OpenConnector(dsn string) (driver.Connector, error) {
+	return NewConnector(dsn, nil)
+}
+
+func openDatabase(dsn string) (*C.duckdb_database, dsnOptions, error) {
 	var (
 		config C.duckdb_config
 		db     C.duckdb_database
-		con    C.duckdb_connection
 	)
+	opts := dsnOptions{stmtCacheSize: defaultStmtCacheSize}
+
 	if err := C.duckdb_create_config(&config); err == C.DuckDBError {
-		return nil, errError
+		return nil, opts, errError
 	}
 
 	defer C.duckdb_destroy_config(&config)
@@ -41,7 +78,7 @@ func (d duckdb) Open(dsn string) (driver.Conn, error) {
 	if pos >= 1 {
 		params, err := url.ParseQuery(dsn[pos+1:])
 		if err != nil {
-			return nil, err
+			return nil, opts, err
 		}
 		mode := ""
 		threads := ""
@@ -49,6 +86,7 @@ func (d duckdb) Open(dsn string) (driver.Conn, error) {
 		defaultOrder := ""
 		if val := params.Get("access_mode"); val != "" {
 			mode = val
+			opts.readOnly = strings.EqualFold(val, "READ_ONLY")
 		}
 		if val := params.Get("threads"); val != "" {
 			threads = val
@@ -59,17 +97,22 @@ func (d duckdb) Open(dsn string) (driver.Conn, error) {
 		if val := params.Get("default_order"); val != "" {
 			defaultOrder = val
 		}
+		if val := params.Get("stmt_cache_size"); val != "" {
+			if n, err := strconv.Atoi(val); err == nil {
+				opts.stmtCacheSize = n
+			}
+		}
 		if mode != "" {
-			d.setConfig(config, "access_mode", mode)
+			setConfig(config, "access_mode", mode)
 		}
 		if threads != "" {
-			d.setConfig(config, "threads", threads)
+			setConfig(config, "threads", threads)
 		}
 		if maxMemory != "" {
-			d.setConfig(config, "max_memory", maxMemory)
+			setConfig(config, "max_memory", maxMemory)
 		}
 		if defaultOrder != "" {
-			d.setConfig(config, "default_order", defaultOrder)
+			setConfig(config, "default_order", defaultOrder)
 		}
 
 		dsn = dsn[:pos]
@@ -79,16 +122,13 @@ func (d duckdb) Open(dsn string) (driver.Conn, error) {
 	defer C.free(unsafe.Pointer(cname))
 
 	if err := C.duckdb_open_ext(cname, &db, config, nil); err == C.DuckDBError {
-		return nil, errError
-	}
-	if err := C.duckdb_connect(db, &con); err == C.DuckDBError {
-		return nil, errError
+		return nil, opts, errError
 	}
 
-	return &conn{db: &db, con: &con}, nil
+	return &db, opts, nil
 }
 
-func (d duckdb) setConfig(config C.duckdb_config, flag, value string) {
+func setConfig(config C.duckdb_config, flag, value string) {
 	modeFlag := C.CString(flag)
 	cValue := C.CString(value)
 	defer C.free(unsafe.Pointer(modeFlag))
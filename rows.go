@@ -0,0 +1,472 @@
+package duckdb
+
+/*
+#include <duckdb.h>
+*/
+import "C"
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+	"time"
+	"unsafe"
+)
+
+var (
+	errInvalidType = errors.New("invalid data type")
+)
+
+// duckdbRows iterates a duckdb_result through the vectorized chunk API
+// instead of the deprecated row-at-a-time duckdb_column_data accessors.
+// It keeps at most one duckdb_data_chunk alive at a time, fetching the next
+// one only once the current chunk's rows are exhausted.
+type duckdbRows struct {
+	r    *C.duckdb_result
+	s    *stmt
+	cols int
+
+	chunkCount int64
+	nextChunk  int64
+
+	chunk     C.duckdb_data_chunk
+	chunkSize int64
+	cursor    int64
+	vectors   []C.duckdb_vector
+}
+
+func newDuckdbRows(res *C.duckdb_result, s *stmt) *duckdbRows {
+	return &duckdbRows{
+		r:          res,
+		s:          s,
+		cols:       int(C.duckdb_column_count(res)),
+		chunkCount: int64(C.duckdb_result_chunk_count(*res)),
+	}
+}
+
+func (r *duckdbRows) Columns() []string {
+	if r.r == nil {
+		panic("database/sql/driver: misuse of duckdb driver: Columns of closed rows")
+	}
+
+	cols := make([]string, r.cols)
+	for i := 0; i < r.cols; i++ {
+		cols[i] = C.GoString(C.duckdb_column_name(r.r, C.idx_t(i)))
+	}
+
+	return cols
+}
+
+// fetchNextChunk destroys the current chunk, if any, and loads the next one
+// from the result. It returns io.EOF once there are no more chunks.
+func (r *duckdbRows) fetchNextChunk() error {
+	if r.chunk != nil {
+		C.duckdb_destroy_data_chunk(&r.chunk)
+		r.chunk = nil
+	}
+
+	if r.nextChunk >= r.chunkCount {
+		return io.EOF
+	}
+
+	r.chunk = C.duckdb_result_get_chunk(*r.r, C.idx_t(r.nextChunk))
+	r.nextChunk++
+	r.chunkSize = int64(C.duckdb_data_chunk_get_size(r.chunk))
+	r.cursor = 0
+
+	r.vectors = make([]C.duckdb_vector, r.cols)
+	for i := 0; i < r.cols; i++ {
+		r.vectors[i] = C.duckdb_data_chunk_get_vector(r.chunk, C.idx_t(i))
+	}
+
+	return nil
+}
+
+func (r *duckdbRows) Next(dst []driver.Value) error {
+	if r.r == nil {
+		panic("database/sql/driver: misuse of duckdb driver: Next of closed rows")
+	}
+
+	for r.chunk == nil || r.cursor >= r.chunkSize {
+		if err := r.fetchNextChunk(); err != nil {
+			return err
+		}
+		// an empty chunk is valid (e.g. the result has zero rows); keep
+		// pulling chunks until we find rows or run out.
+		if r.chunkSize == 0 {
+			continue
+		}
+	}
+
+	for i := 0; i < r.cols; i++ {
+		vec := r.vectors[i]
+		validity := C.duckdb_vector_get_validity(vec)
+		if validity != nil && !bool(C.duckdb_validity_row_is_valid(validity, C.idx_t(r.cursor))) {
+			dst[i] = nil
+			continue
+		}
+
+		v, err := decodeVectorValue(vec, C.idx_t(r.cursor))
+		if err != nil {
+			return err
+		}
+		dst[i] = v
+	}
+
+	r.cursor++
+
+	return nil
+}
+
+// decodeVectorValue decodes the value at row out of vec, recursing into
+// LIST/STRUCT/MAP children. It is used both for a result's top-level
+// columns and for nested container children, since duckdb_vector_get_column_type
+// reports the same type duckdb_column_type would for a top-level column.
+func decodeVectorValue(vec C.duckdb_vector, row C.idx_t) (driver.Value, error) {
+	validity := C.duckdb_vector_get_validity(vec)
+	if validity != nil && !bool(C.duckdb_validity_row_is_valid(validity, row)) {
+		return nil, nil
+	}
+
+	lt := C.duckdb_vector_get_column_type(vec)
+	defer C.duckdb_destroy_logical_type(&lt)
+	colData := C.duckdb_vector_get_data(vec)
+
+	switch C.duckdb_get_type_id(lt) {
+	case C.DUCKDB_TYPE_INVALID:
+		return nil, errInvalidType
+	case C.DUCKDB_TYPE_BOOLEAN:
+		return (*[1 << 31]bool)(colData)[row], nil
+	case C.DUCKDB_TYPE_TINYINT:
+		return (*[1 << 31]int8)(colData)[row], nil
+	case C.DUCKDB_TYPE_SMALLINT:
+		return (*[1 << 31]int16)(colData)[row], nil
+	case C.DUCKDB_TYPE_INTEGER:
+		return (*[1 << 31]int32)(colData)[row], nil
+	case C.DUCKDB_TYPE_BIGINT:
+		return (*[1 << 31]int64)(colData)[row], nil
+	case C.DUCKDB_TYPE_UTINYINT:
+		return (*[1 << 31]uint8)(colData)[row], nil
+	case C.DUCKDB_TYPE_USMALLINT:
+		return (*[1 << 31]uint16)(colData)[row], nil
+	case C.DUCKDB_TYPE_UINTEGER:
+		return (*[1 << 31]uint32)(colData)[row], nil
+	case C.DUCKDB_TYPE_UBIGINT:
+		return (*[1 << 31]uint64)(colData)[row], nil
+	case C.DUCKDB_TYPE_FLOAT:
+		return (*[1 << 31]float32)(colData)[row], nil
+	case C.DUCKDB_TYPE_DOUBLE:
+		return (*[1 << 31]float64)(colData)[row], nil
+	case C.DUCKDB_TYPE_DATE:
+		date := (*[1 << 31]C.duckdb_date)(colData)[row]
+		val := C.duckdb_from_date(date)
+		return time.Date(int(val.year), time.Month(val.month), int(val.day), 0, 0, 0, 0, time.UTC), nil
+	case C.DUCKDB_TYPE_TIME:
+		t := (*[1 << 31]C.duckdb_time)(colData)[row]
+		val := C.duckdb_from_time(t)
+		return time.Date(1970, time.January, 1, int(val.hour), int(val.min), int(val.sec), int(val.micros)*1000, time.UTC), nil
+	case C.DUCKDB_TYPE_TIMESTAMP:
+		ts := (*[1 << 31]C.duckdb_timestamp)(colData)[row]
+		val := C.duckdb_from_timestamp(ts)
+		return time.Date(
+			int(val.date.year),
+			time.Month(val.date.month),
+			int(val.date.day),
+			int(val.time.hour),
+			int(val.time.min),
+			int(val.time.sec),
+			int(val.time.micros)*1000,
+			time.UTC,
+		), nil
+	case C.DUCKDB_TYPE_INTERVAL:
+		iv := (*[1 << 31]C.duckdb_interval)(colData)[row]
+		return Interval{Months: int32(iv.months), Days: int32(iv.days), Micros: int64(iv.micros)}, nil
+	case C.DUCKDB_TYPE_HUGEINT:
+		return hugeintToBigInt((*[1 << 31]C.duckdb_hugeint)(colData)[row]), nil
+	case C.DUCKDB_TYPE_UUID:
+		return hugeintToUUIDString((*[1 << 31]C.duckdb_hugeint)(colData)[row]), nil
+	case C.DUCKDB_TYPE_DECIMAL:
+		return decodeDecimal(lt, colData, int64(row))
+	case C.DUCKDB_TYPE_VARCHAR:
+		return readVectorString(colData, int64(row)), nil
+	case C.DUCKDB_TYPE_BLOB:
+		return readVectorBlob(colData, int64(row)), nil
+	case C.DUCKDB_TYPE_LIST:
+		return decodeListVector(vec, row)
+	case C.DUCKDB_TYPE_STRUCT:
+		return decodeStructVector(vec, row)
+	case C.DUCKDB_TYPE_MAP:
+		return decodeMapVector(vec, row)
+	}
+
+	return nil, errInvalidType
+}
+
+// decodeListVector decodes the LIST entry at row into a []any, recursing
+// into the child vector DuckDB stores all list elements in (flattened across
+// every row of vec).
+func decodeListVector(vec C.duckdb_vector, row C.idx_t) (driver.Value, error) {
+	colData := C.duckdb_vector_get_data(vec)
+	entry := (*[1 << 31]C.duckdb_list_entry)(colData)[row]
+	child := C.duckdb_list_vector_get_child(vec)
+
+	result := make([]any, 0, entry.length)
+	for i := C.idx_t(0); i < entry.length; i++ {
+		v, err := decodeVectorValue(child, entry.offset+i)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, v)
+	}
+
+	return result, nil
+}
+
+// decodeStructVector decodes the STRUCT entry at row into a map[string]any,
+// one child vector per field.
+func decodeStructVector(vec C.duckdb_vector, row C.idx_t) (driver.Value, error) {
+	lt := C.duckdb_vector_get_column_type(vec)
+	defer C.duckdb_destroy_logical_type(&lt)
+
+	count := C.duckdb_struct_type_child_count(lt)
+	result := make(map[string]any, int(count))
+	for i := C.idx_t(0); i < count; i++ {
+		name := C.duckdb_struct_type_child_name(lt, i)
+		child := C.duckdb_struct_vector_get_child(vec, i)
+
+		v, err := decodeVectorValue(child, row)
+		key := C.GoString(name)
+		C.duckdb_free(unsafe.Pointer(name))
+		if err != nil {
+			return nil, err
+		}
+
+		result[key] = v
+	}
+
+	return result, nil
+}
+
+// decodeMapVector decodes the MAP entry at row into a map[string]any. DuckDB
+// stores MAP as a LIST<STRUCT<key, value>>, so it's decoded the same way as
+// a list and the key/value pairs are folded into a Go map, using the key's
+// string representation (MAP keys need not be strings) as the map key.
+func decodeMapVector(vec C.duckdb_vector, row C.idx_t) (driver.Value, error) {
+	colData := C.duckdb_vector_get_data(vec)
+	entry := (*[1 << 31]C.duckdb_list_entry)(colData)[row]
+	entries := C.duckdb_list_vector_get_child(vec)
+	keys := C.duckdb_struct_vector_get_child(entries, 0)
+	values := C.duckdb_struct_vector_get_child(entries, 1)
+
+	result := make(map[string]any, int(entry.length))
+	for i := C.idx_t(0); i < entry.length; i++ {
+		idx := entry.offset + i
+
+		k, err := decodeVectorValue(keys, idx)
+		if err != nil {
+			return nil, err
+		}
+		v, err := decodeVectorValue(values, idx)
+		if err != nil {
+			return nil, err
+		}
+
+		result[fmt.Sprint(k)] = v
+	}
+
+	return result, nil
+}
+
+// duckdbStringT mirrors duckdb_string_t's memory layout: a uint32 length
+// followed by 12 bytes that hold either the inlined string (len <= 12) or a
+// 4-byte prefix plus an 8-byte pointer to the out-of-line data.
+type duckdbStringT struct {
+	length uint32
+	data   [12]byte
+}
+
+const stringInlineLength = 12
+
+func readVectorString(colData unsafe.Pointer, row int64) string {
+	s := (*[1 << 31]duckdbStringT)(colData)[row]
+	if s.length <= stringInlineLength {
+		return string(s.data[:s.length])
+	}
+
+	ptr := *(**C.char)(unsafe.Pointer(&s.data[4]))
+	return C.GoStringN(ptr, C.int(s.length))
+}
+
+func readVectorBlob(colData unsafe.Pointer, row int64) []byte {
+	s := (*[1 << 31]duckdbStringT)(colData)[row]
+	if s.length <= stringInlineLength {
+		return append([]byte(nil), s.data[:s.length]...)
+	}
+
+	ptr := *(**C.char)(unsafe.Pointer(&s.data[4]))
+	return C.GoBytes(unsafe.Pointer(ptr), C.int(s.length))
+}
+
+// decodeDecimal reads the width/scale off lt and decodes the unscaled value
+// out of the storage type DuckDB chose for it. lt is borrowed: the caller
+// retains ownership and must destroy it.
+func decodeDecimal(lt C.duckdb_logical_type, colData unsafe.Pointer, row int64) (Decimal, error) {
+	width := uint8(C.duckdb_decimal_width(lt))
+	scale := uint8(C.duckdb_decimal_scale(lt))
+
+	var value *big.Int
+	switch C.duckdb_decimal_internal_type(lt) {
+	case C.DUCKDB_TYPE_SMALLINT:
+		value = big.NewInt(int64((*[1 << 31]int16)(colData)[row]))
+	case C.DUCKDB_TYPE_INTEGER:
+		value = big.NewInt(int64((*[1 << 31]int32)(colData)[row]))
+	case C.DUCKDB_TYPE_BIGINT:
+		value = big.NewInt(int64((*[1 << 31]int64)(colData)[row]))
+	case C.DUCKDB_TYPE_HUGEINT:
+		value = hugeintToBigInt((*[1 << 31]C.duckdb_hugeint)(colData)[row])
+	default:
+		return Decimal{}, errInvalidType
+	}
+
+	return Decimal{Width: width, Scale: scale, Value: value}, nil
+}
+
+// hugeintToUUIDString converts DuckDB's hugeint-backed UUID storage (the
+// 128-bit value is offset by 2^63 in the upper half for ordering) into a
+// canonical UUID string.
+func hugeintToUUIDString(h C.duckdb_hugeint) string {
+	upper := uint64(h.upper) ^ (uint64(1) << 63)
+	lower := uint64(h.lower)
+
+	var b [16]byte
+	for i := 0; i < 8; i++ {
+		b[i] = byte(upper >> (8 * (7 - i)))
+		b[8+i] = byte(lower >> (8 * (7 - i)))
+	}
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// implements driver.RowsColumnTypeScanType
+func (r *duckdbRows) ColumnTypeScanType(index int) reflect.Type {
+	colType := C.duckdb_column_type(r.r, C.idx_t(index))
+	switch colType {
+	case C.DUCKDB_TYPE_BOOLEAN:
+		return reflect.TypeOf(true)
+	case C.DUCKDB_TYPE_TINYINT:
+		return reflect.TypeOf(int8(0))
+	case C.DUCKDB_TYPE_SMALLINT:
+		return reflect.TypeOf(int16(0))
+	case C.DUCKDB_TYPE_INTEGER:
+		return reflect.TypeOf(int(0))
+	case C.DUCKDB_TYPE_BIGINT:
+		return reflect.TypeOf(int64(0))
+	case C.DUCKDB_TYPE_FLOAT:
+		return reflect.TypeOf(float32(0))
+	case C.DUCKDB_TYPE_DOUBLE:
+		return reflect.TypeOf(float64(0))
+	case C.DUCKDB_TYPE_DATE, C.DUCKDB_TYPE_TIMESTAMP, C.DUCKDB_TYPE_TIME:
+		return reflect.TypeOf(time.Time{})
+	case C.DUCKDB_TYPE_VARCHAR, C.DUCKDB_TYPE_UUID:
+		return reflect.TypeOf("")
+	case C.DUCKDB_TYPE_UTINYINT:
+		return reflect.TypeOf(uint8(0))
+	case C.DUCKDB_TYPE_USMALLINT:
+		return reflect.TypeOf(uint16(0))
+	case C.DUCKDB_TYPE_UINTEGER:
+		return reflect.TypeOf(uint32(0))
+	case C.DUCKDB_TYPE_UBIGINT:
+		return reflect.TypeOf(uint64(0))
+	case C.DUCKDB_TYPE_HUGEINT:
+		return reflect.TypeOf((*big.Int)(nil))
+	case C.DUCKDB_TYPE_DECIMAL:
+		return reflect.TypeOf(Decimal{})
+	case C.DUCKDB_TYPE_BLOB:
+		return reflect.TypeOf([]byte(nil))
+	case C.DUCKDB_TYPE_INTERVAL:
+		return reflect.TypeOf(Interval{})
+	case C.DUCKDB_TYPE_LIST:
+		return reflect.TypeOf([]any(nil))
+	case C.DUCKDB_TYPE_STRUCT, C.DUCKDB_TYPE_MAP:
+		return reflect.TypeOf(map[string]any(nil))
+	}
+	return nil
+}
+
+// implements driver.RowsColumnTypeScanType
+func (r *duckdbRows) ColumnTypeDatabaseTypeName(index int) string {
+	colType := C.duckdb_column_type(r.r, C.idx_t(index))
+	switch colType {
+	case C.DUCKDB_TYPE_BOOLEAN:
+		return "BOOLEAN"
+	case C.DUCKDB_TYPE_TINYINT:
+		return "TINYINT"
+	case C.DUCKDB_TYPE_SMALLINT:
+		return "SMALLINT"
+	case C.DUCKDB_TYPE_INTEGER:
+		return "INT"
+	case C.DUCKDB_TYPE_BIGINT:
+		return "BIGINT"
+	case C.DUCKDB_TYPE_FLOAT:
+		return "FLOAT"
+	case C.DUCKDB_TYPE_DOUBLE:
+		return "DOUBLE"
+	case C.DUCKDB_TYPE_DATE:
+		return "DATE"
+	case C.DUCKDB_TYPE_VARCHAR:
+		return "VARCHAR"
+	case C.DUCKDB_TYPE_TIMESTAMP:
+		return "TIMESTAMP"
+	case C.DUCKDB_TYPE_TIME:
+		return "TIME"
+	case C.DUCKDB_TYPE_UTINYINT:
+		return "UTINYINT"
+	case C.DUCKDB_TYPE_USMALLINT:
+		return "USMALLINT"
+	case C.DUCKDB_TYPE_UINTEGER:
+		return "UINTEGER"
+	case C.DUCKDB_TYPE_UBIGINT:
+		return "UBIGINT"
+	case C.DUCKDB_TYPE_HUGEINT:
+		return "HUGEINT"
+	case C.DUCKDB_TYPE_DECIMAL:
+		return "DECIMAL"
+	case C.DUCKDB_TYPE_BLOB:
+		return "BLOB"
+	case C.DUCKDB_TYPE_INTERVAL:
+		return "INTERVAL"
+	case C.DUCKDB_TYPE_UUID:
+		return "UUID"
+	case C.DUCKDB_TYPE_LIST:
+		return "LIST"
+	case C.DUCKDB_TYPE_STRUCT:
+		return "STRUCT"
+	case C.DUCKDB_TYPE_MAP:
+		return "MAP"
+	}
+	return ""
+}
+
+func (r *duckdbRows) Close() error {
+	if r.r == nil {
+		panic("database/sql/driver: misuse of duckdb driver: Close of already closed rows")
+	}
+
+	if r.chunk != nil {
+		C.duckdb_destroy_data_chunk(&r.chunk)
+		r.chunk = nil
+	}
+
+	C.duckdb_destroy_result(r.r)
+
+	r.r = nil
+	if r.s != nil {
+		r.s.rows = false
+		r.s = nil
+	}
+
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"database/sql/driver"
 	"errors"
 	"fmt"
+	"math/big"
 	"sync"
 	"time"
 	"unsafe"
@@ -42,6 +43,15 @@ func (s *stmt) Close() error {
 	return nil
 }
 
+// destroy releases the underlying duckdb_prepared_statement directly,
+// bypassing the active-rows/double-close guards Close enforces for
+// database/sql. It is only used by the driver itself (stmtCache eviction and
+// the one-off statements conn.Query/conn.Exec own), never exposed as
+// driver.Stmt.
+func (s *stmt) destroy() {
+	C.duckdb_destroy_prepare(s.stmt)
+}
+
 func (s *stmt) NumInput() int {
 	var pc C.idx_t
 	pc = C.duckdb_nparams(*s.stmt)
@@ -55,6 +65,55 @@ func (s *stmt) start(args []driver.Value) error {
 
 	for i, v := range args {
 		switch v := v.(type) {
+		case nil:
+			if rv := C.duckdb_bind_null(*s.stmt, C.idx_t(i+1)); rv == C.DuckDBError {
+				return errCouldNotBind
+			}
+			continue
+		case uint8:
+			if rv := C.duckdb_bind_uint8(*s.stmt, C.idx_t(i+1), C.uint8_t(v)); rv == C.DuckDBError {
+				return errCouldNotBind
+			}
+			continue
+		case uint16:
+			if rv := C.duckdb_bind_uint16(*s.stmt, C.idx_t(i+1), C.uint16_t(v)); rv == C.DuckDBError {
+				return errCouldNotBind
+			}
+			continue
+		case uint32:
+			if rv := C.duckdb_bind_uint32(*s.stmt, C.idx_t(i+1), C.uint32_t(v)); rv == C.DuckDBError {
+				return errCouldNotBind
+			}
+			continue
+		case uint64:
+			if rv := C.duckdb_bind_uint64(*s.stmt, C.idx_t(i+1), C.uint64_t(v)); rv == C.DuckDBError {
+				return errCouldNotBind
+			}
+			continue
+		case []byte:
+			var ptr unsafe.Pointer
+			if len(v) > 0 {
+				ptr = unsafe.Pointer(&v[0])
+			}
+			if rv := C.duckdb_bind_blob(*s.stmt, C.idx_t(i+1), ptr, C.idx_t(len(v))); rv == C.DuckDBError {
+				return errCouldNotBind
+			}
+			continue
+		case *big.Int:
+			if rv := C.duckdb_bind_hugeint(*s.stmt, C.idx_t(i+1), bigIntToHugeint(v)); rv == C.DuckDBError {
+				return errCouldNotBind
+			}
+			continue
+		case Interval:
+			iv := C.duckdb_interval{
+				months: C.int32_t(v.Months),
+				days:   C.int32_t(v.Days),
+				micros: C.int64_t(v.Micros),
+			}
+			if rv := C.duckdb_bind_interval(*s.stmt, C.idx_t(i+1), iv); rv == C.DuckDBError {
+				return errCouldNotBind
+			}
+			continue
 		case int8:
 			if rv := C.duckdb_bind_int8(*s.stmt, C.idx_t(i+1), C.int8_t(v)); rv == C.DuckDBError {
 				return errCouldNotBind
@@ -75,6 +134,16 @@ func (s *stmt) start(args []driver.Value) error {
 				return errCouldNotBind
 			}
 			continue
+		case int:
+			if rv := C.duckdb_bind_int64(*s.stmt, C.idx_t(i+1), C.int64_t(v)); rv == C.DuckDBError {
+				return errCouldNotBind
+			}
+			continue
+		case float32:
+			if rv := C.duckdb_bind_double(*s.stmt, C.idx_t(i+1), C.double(v)); rv == C.DuckDBError {
+				return errCouldNotBind
+			}
+			continue
 		case float64:
 			if rv := C.duckdb_bind_double(*s.stmt, C.idx_t(i+1), C.double(v)); rv == C.DuckDBError {
 				return errCouldNotBind
@@ -147,12 +216,24 @@ func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
 		return nil, err
 	}
 
-	s.rows = true
 	var res C.duckdb_result
-
 	if err := C.duckdb_execute_prepared(*s.stmt, &res); err == C.DuckDBError {
+		defer C.duckdb_destroy_result(&res)
 		return nil, errors.New(C.GoString(C.duckdb_result_error(&res)))
 	}
 
-	return &duckdbRows{r: &res, s: s}, nil
+	// Only mark rows active once execution has actually succeeded, so a
+	// runtime SQL error (e.g. a cast or constraint violation) never leaves a
+	// cached, long-lived *stmt permanently stuck with rows active.
+	s.rows = true
+
+	return newDuckdbRows(&res, s), nil
+}
+
+// CheckNamedValue implements driver.NamedValueChecker so that database/sql
+// passes through the extra types start accepts (nil, []byte, *big.Int,
+// Interval, the unsigned ints, sql.NullXxx) instead of rejecting them with
+// driver.ErrSkip.
+func (s *stmt) CheckNamedValue(nv *driver.NamedValue) error {
+	return checkNamedValue(nv)
 }
@@ -0,0 +1,98 @@
+package duckdb
+
+import (
+	"container/list"
+	"sync"
+)
+
+// stmtCache bounds the prepared statements kept alive for a single
+// connection, keyed by query text, so that conn.Query/conn.Exec reuse the
+// underlying duckdb_prepared_statement for hot queries instead of
+// re-parsing on every call. It is not safe for concurrent use across
+// connections; each conn owns one.
+type stmtCache struct {
+	mu      sync.Mutex
+	cap     int
+	ll      *list.List
+	entries map[string]*list.Element
+}
+
+type stmtCacheEntry struct {
+	query string
+	stmt  *stmt
+}
+
+// newStmtCache builds a cache bounded to cap entries. cap <= 0 disables
+// caching: prepare always returns a fresh, caller-owned statement.
+func newStmtCache(cap int) *stmtCache {
+	return &stmtCache{
+		cap:     cap,
+		ll:      list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// prepare returns the cached prepared statement for query if present,
+// otherwise prepares a new one on c and inserts it into the cache, evicting
+// the least-recently-used entry if the cache is at capacity.
+//
+// The returned owned flag reports whether the *stmt is a one-off that the
+// cache isn't tracking: when true (caching disabled, or sc is nil) the
+// caller is responsible for destroying it after use, since nothing else
+// ever will.
+func (sc *stmtCache) prepare(c *conn, query string) (s *stmt, owned bool, err error) {
+	if sc == nil || sc.cap <= 0 {
+		s, err = c.prepareNew(query)
+		return s, true, err
+	}
+
+	sc.mu.Lock()
+	if el, ok := sc.entries[query]; ok {
+		sc.ll.MoveToFront(el)
+		s := el.Value.(*stmtCacheEntry).stmt
+		sc.mu.Unlock()
+		return s, false, nil
+	}
+	sc.mu.Unlock()
+
+	s, err = c.prepareNew(query)
+	if err != nil {
+		return nil, false, err
+	}
+
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	el := sc.ll.PushFront(&stmtCacheEntry{query: query, stmt: s})
+	sc.entries[query] = el
+
+	for sc.ll.Len() > sc.cap {
+		oldest := sc.ll.Back()
+		if oldest == nil {
+			break
+		}
+		entry := oldest.Value.(*stmtCacheEntry)
+		sc.ll.Remove(oldest)
+		delete(sc.entries, entry.query)
+		entry.stmt.destroy()
+	}
+
+	return s, false, nil
+}
+
+// close destroys every cached prepared statement. Called when the owning
+// conn is closed.
+func (sc *stmtCache) close() {
+	if sc == nil {
+		return
+	}
+
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	for _, el := range sc.entries {
+		el.Value.(*stmtCacheEntry).stmt.destroy()
+	}
+	sc.ll.Init()
+	sc.entries = make(map[string]*list.Element)
+}
@@ -0,0 +1,88 @@
+//go:build duckdb_integration
+
+package duckdb
+
+import (
+	"context"
+	"testing"
+)
+
+// These tests open a real in-memory duckdb database, so they're gated
+// behind the duckdb_integration build tag and only run where libduckdb is
+// available: `go test -tags duckdb_integration ./...`.
+
+func openTestConn(t *testing.T, dsn string) *conn {
+	t.Helper()
+
+	connector, err := NewConnector(dsn, nil)
+	if err != nil {
+		t.Fatalf("NewConnector: %v", err)
+	}
+
+	dc, err := connector.Connect(context.Background())
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+
+	c, ok := dc.(*conn)
+	if !ok {
+		t.Fatalf("Connect returned %T, want *conn", dc)
+	}
+
+	t.Cleanup(func() { _ = c.Close() })
+
+	return c
+}
+
+func TestStmtCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	sc := newStmtCache(2)
+	c := openTestConn(t, ":memory:?stmt_cache_size=2")
+
+	s1, owned, err := sc.prepare(c, "SELECT 1")
+	if err != nil || owned {
+		t.Fatalf("prepare SELECT 1: stmt=%v owned=%v err=%v", s1, owned, err)
+	}
+	if _, _, err := sc.prepare(c, "SELECT 2"); err != nil {
+		t.Fatalf("prepare SELECT 2: %v", err)
+	}
+
+	// Touch "SELECT 1" so it becomes the most recently used entry, then
+	// insert a third query: "SELECT 2" (least recently used) should be the
+	// one evicted, not "SELECT 1".
+	if _, _, err := sc.prepare(c, "SELECT 1"); err != nil {
+		t.Fatalf("re-prepare SELECT 1: %v", err)
+	}
+	if _, _, err := sc.prepare(c, "SELECT 3"); err != nil {
+		t.Fatalf("prepare SELECT 3: %v", err)
+	}
+
+	if _, ok := sc.entries["SELECT 2"]; ok {
+		t.Errorf("SELECT 2 should have been evicted as least recently used")
+	}
+	if _, ok := sc.entries["SELECT 1"]; !ok {
+		t.Errorf("SELECT 1 should still be cached (it was touched before eviction)")
+	}
+	if sc.ll.Len() != 2 {
+		t.Errorf("cache should hold exactly cap=2 entries, holds %d", sc.ll.Len())
+	}
+
+	sc.close()
+}
+
+func TestStmtCacheDisabledReturnsOwnedStmt(t *testing.T) {
+	sc := newStmtCache(0)
+	c := openTestConn(t, ":memory:?stmt_cache_size=0")
+
+	s, owned, err := sc.prepare(c, "SELECT 1")
+	if err != nil {
+		t.Fatalf("prepare: %v", err)
+	}
+	if !owned {
+		t.Fatalf("prepare with cap<=0 must report owned=true so the caller destroys the statement")
+	}
+	if len(sc.entries) != 0 {
+		t.Errorf("disabled cache should never track entries, got %d", len(sc.entries))
+	}
+
+	s.destroy()
+}
@@ -0,0 +1,45 @@
+package duckdb
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Interval represents a DuckDB INTERVAL value. DuckDB stores intervals as
+// separate months/days/microseconds components rather than a single
+// duration, since a month has no fixed length.
+type Interval struct {
+	Months int32
+	Days   int32
+	Micros int64
+}
+
+// Decimal represents a DuckDB DECIMAL(width, scale) value as its unscaled
+// integer together with the width/scale needed to place the decimal point.
+type Decimal struct {
+	Width uint8
+	Scale uint8
+	Value *big.Int
+}
+
+func (d Decimal) String() string {
+	if d.Value == nil {
+		return "<nil>"
+	}
+	if d.Scale == 0 {
+		return d.Value.String()
+	}
+
+	s := new(big.Int).Abs(d.Value).String()
+	for len(s) <= int(d.Scale) {
+		s = "0" + s
+	}
+	intPart, fracPart := s[:len(s)-int(d.Scale)], s[len(s)-int(d.Scale):]
+
+	sign := ""
+	if d.Value.Sign() < 0 {
+		sign = "-"
+	}
+
+	return fmt.Sprintf("%s%s.%s", sign, intPart, fracPart)
+}